@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dsoprea/go-exif/v3"
+)
+
+// extractionError wraps a failure encountered while reading EXIF data from a file so
+// callers can classify it (IsCriticalError/IsMalformedIfdError/IsShortReadTagValueError)
+// instead of always discarding the file the way a bare error from the underlying
+// go-exif calls would force us to.
+type extractionError struct {
+	Stage string // "search" (SearchAndExtractExif) or "flatten" (GetFlatExifDataUniversalSearch)
+	Path  string
+	Err   error
+}
+
+func (e *extractionError) Error() string {
+	return fmt.Sprintf("exif %s error for file: %s, with error: %s", e.Stage, e.Path, e.Err)
+}
+
+func (e *extractionError) Unwrap() error {
+	return e.Err
+}
+
+// IsMalformedIfdError reports whether err stems from a single bad IFD entry -
+// exif.ErrTagTypeNotValid (a tag's on-disk type disagrees with the tag index) or
+// exif.ErrOffsetInvalid (an IFD offset points outside the file) - rather than a
+// structurally broken EXIF block. The GPS IFD is parsed with the same generic
+// code path as every other IFD, so a corrupt GPS IFD surfaces as one of these,
+// not as exif.ErrGpsCoordinatesNotValid (that sentinel is only ever panicked from
+// GpsInfo.S2CellId, which this tool never calls). These are non-fatal: the tags
+// already read before the bad entry are still usable.
+func IsMalformedIfdError(err error) bool {
+	return errors.Is(err, exif.ErrTagTypeNotValid) || errors.Is(err, exif.ErrOffsetInvalid)
+}
+
+// IsShortReadTagValueError reports whether err comes from a tag value that was
+// truncated in the file (an unexpected EOF while reading a value's bytes). The IFD
+// entries already visited before the truncation are still usable.
+func IsShortReadTagValueError(err error) bool {
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// IsCriticalError reports whether err should cause a file to be dropped entirely
+// rather than emitted with the tags that were readable and a warning attached.
+func IsCriticalError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return !IsMalformedIfdError(err) && !IsShortReadTagValueError(err)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/dsoprea/go-exif/v3"
+)
+
+func TestIsMalformedIfdError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "tag type not valid", err: exif.ErrTagTypeNotValid, want: true},
+		{name: "offset invalid", err: exif.ErrOffsetInvalid, want: true},
+		{name: "wrapped", err: &extractionError{Stage: "flatten", Err: exif.ErrOffsetInvalid}, want: true},
+		{name: "unrelated error", err: io.ErrUnexpectedEOF, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsMalformedIfdError(tt.err); got != tt.want {
+				t.Errorf("IsMalformedIfdError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCriticalError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil is not critical", err: nil, want: false},
+		{name: "malformed IFD is not critical", err: exif.ErrTagTypeNotValid, want: false},
+		{name: "short read is not critical", err: io.ErrUnexpectedEOF, want: false},
+		{name: "unknown error is critical", err: exif.ErrNoExif, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCriticalError(tt.err); got != tt.want {
+				t.Errorf("IsCriticalError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/barasher/go-exiftool"
+)
+
+// exiftoolDateFormat is the strftime layout passed to exiftool so DateTimeOriginal
+// comes back in the same "2006:01:02 15:04:05" shape the go-exif backend produces.
+const exiftoolDateFormat = "%Y:%m:%d %H:%M:%S"
+
+// exiftoolParser is an ExifParser backed by a single long-lived `exiftool -stay_open`
+// process (via barasher/go-exiftool), reused across every Parse call instead of
+// shelling out per file. It covers formats the pure-Go go-exif library can't decode,
+// such as HEIC/HEIF, common RAW formats and video containers.
+type exiftoolParser struct {
+	et *exiftool.Exiftool
+}
+
+// newExiftoolParser starts the backing exiftool process. Callers must call Close
+// when done scanning to let the process exit cleanly.
+func newExiftoolParser() (*exiftoolParser, error) {
+	et, err := exiftool.NewExiftool(exiftool.NoPrintConversion(), exiftool.DateFormant(exiftoolDateFormat))
+	if err != nil {
+		return nil, fmt.Errorf("error starting exiftool: %s", err)
+	}
+	return &exiftoolParser{et: et}, nil
+}
+
+func (p *exiftoolParser) Close() error {
+	return p.et.Close()
+}
+
+func (p *exiftoolParser) Parse(path string) (*exifData, error) {
+	metadata := p.et.ExtractMetadata(path)
+	if len(metadata) == 0 {
+		return nil, fmt.Errorf("exiftool returned no metadata for file: %s", path)
+	}
+
+	fileMetadata := metadata[0]
+	if fileMetadata.Err != nil {
+		return nil, fmt.Errorf("error extracting exiftool metadata from file: %s, with error: %s", path, fileMetadata.Err)
+	}
+
+	return exifDataFromExiftoolMetadata(path, fileMetadata), nil
+}
+
+// exifDataFromExiftoolMetadata converts a successful exiftool extraction into an
+// exifData. It's split out from Parse so the conversion can be unit tested
+// against hand-built exiftool.FileMetadata values without shelling out.
+func exifDataFromExiftoolMetadata(path string, fileMetadata exiftool.FileMetadata) *exifData {
+	data := &exifData{FilePath: path}
+
+	// GPSLatitude/GPSLongitude/GPSAltitude (requested with no group prefix) resolve
+	// to exiftool's Composite tags, whose own ValueConv already folds in
+	// GPSLatitudeRef/GPSLongitudeRef/GPSAltitudeRef - these are signed values, not
+	// raw magnitudes, so no separate ref check is needed (or correct) here.
+	latitude, latErr := fileMetadata.GetFloat(GPSLatitude)
+	longitude, longErr := fileMetadata.GetFloat(GPSLongitude)
+	if latErr == nil && longErr == nil {
+		if isValidGPSCoordinate(latitude, longitude) {
+			data.LatitudeDecimal = latitude
+			data.LongitudeDecimal = longitude
+			data.HasDecimalCoordinates = true
+			data.Latitude = formatDecimalCoordinate(latitude, "N", "S")
+			data.Longitude = formatDecimalCoordinate(longitude, "E", "W")
+		} else {
+			log.Printf("discarding implausible GPS fix (%f, %f) for file: %s", latitude, longitude, path)
+		}
+	}
+	if data.Latitude == "" {
+		data.Latitude = "Not available"
+	}
+	if data.Longitude == "" {
+		data.Longitude = "Not available"
+	}
+
+	if timestamp, err := fileMetadata.GetString(DateTimeOriginal); err == nil {
+		data.Timestamp = timestamp
+	}
+	cameraMake, _ := fileMetadata.GetString(Make)
+	model, _ := fileMetadata.GetString(Model)
+	data.Camera = formatCamera(cameraMake, model)
+
+	if orientation, err := fileMetadata.GetInt(Orientation); err == nil {
+		data.Orientation = formatOrientation(uint16(orientation), true)
+	} else {
+		data.Orientation = "Not available"
+	}
+
+	if altitude, err := fileMetadata.GetFloat(GPSAltitude); err == nil {
+		data.Altitude = formatSignedAltitude(altitude, true)
+	} else {
+		data.Altitude = "Not available"
+	}
+
+	if data.Timestamp != "" && data.HasDecimalCoordinates {
+		if zonedTimestamp, tzErr := correctTimestampZone(data.Timestamp, data.LatitudeDecimal, data.LongitudeDecimal); tzErr == nil {
+			data.Timestamp = zonedTimestamp
+		} else {
+			log.Printf("keeping local timestamp for file: %s, timezone lookup failed: %s", path, tzErr)
+		}
+	}
+
+	return data
+}
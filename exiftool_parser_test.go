@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/barasher/go-exiftool"
+)
+
+func TestExifDataFromExiftoolMetadata_Altitude(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   map[string]interface{}
+		wantText string
+	}{
+		{
+			name: "above sea level composite already signed positive",
+			fields: map[string]interface{}{
+				"GPSAltitude": 123.4,
+			},
+			wantText: "123.40m",
+		},
+		{
+			name: "below sea level composite already signed negative",
+			fields: map[string]interface{}{
+				"GPSAltitude":    -12.5,
+				"GPSAltitudeRef": int64(1),
+			},
+			wantText: "-12.50m",
+		},
+		{
+			name:     "absent altitude",
+			fields:   map[string]interface{}{},
+			wantText: "Not available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm := exiftool.FileMetadata{File: "test.jpg", Fields: tt.fields}
+			data := exifDataFromExiftoolMetadata("test.jpg", fm)
+			if data.Altitude != tt.wantText {
+				t.Errorf("Altitude = %q, want %q", data.Altitude, tt.wantText)
+			}
+		})
+	}
+}
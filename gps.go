@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	exifCommon "github.com/dsoprea/go-exif/v3/common"
+)
+
+// parseGPSPosition renders a GPS rational triple as a degrees/minutes/seconds string.
+func parseGPSPosition(gpsPosArr []exifCommon.Rational) (position string) {
+	if gpsPosArr[0].Denominator != 0 {
+		position = strconv.Itoa(int(gpsPosArr[0].Numerator / gpsPosArr[0].Denominator))
+	} else {
+		position = "0"
+	}
+	position += "Â°"
+	if gpsPosArr[1].Denominator != 0 {
+		position += strconv.Itoa(int(gpsPosArr[1].Numerator / gpsPosArr[1].Denominator))
+	} else {
+		position += "0"
+	}
+	position += "'"
+	if gpsPosArr[2].Denominator != 0 {
+		position += fmt.Sprintf("%.2f''", float32(gpsPosArr[2].Numerator)/float32(gpsPosArr[2].Denominator))
+	} else {
+		position += "0''"
+	}
+	return
+}
+
+// parseGPSDecimal converts a GPS rational triple (degrees, minutes, seconds) into
+// signed decimal degrees, applying the hemisphere reference so that South and West
+// values come out negative.
+func parseGPSDecimal(gpsPosArr []exifCommon.Rational, ref string) (float64, error) {
+	degrees, err := gpsRationalToFloat(gpsPosArr[0])
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := gpsRationalToFloat(gpsPosArr[1])
+	if err != nil {
+		return 0, err
+	}
+	seconds, err := gpsRationalToFloat(gpsPosArr[2])
+	if err != nil {
+		return 0, err
+	}
+
+	decimal := degrees + minutes/60 + seconds/3600
+	if ref == "S" || ref == "W" {
+		decimal = -decimal
+	}
+	return decimal, nil
+}
+
+// isValidGPSCoordinate rejects GPS fixes that cannot be real: out-of-range latitude
+// or longitude, a non-finite value produced by a malformed rational, and the (0, 0)
+// "no fix" sentinel some cameras and phones write when satellite lock was never
+// acquired.
+func isValidGPSCoordinate(latitude, longitude float64) bool {
+	if math.IsNaN(latitude) || math.IsNaN(longitude) || math.IsInf(latitude, 0) || math.IsInf(longitude, 0) {
+		return false
+	}
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return false
+	}
+	if latitude == 0 && longitude == 0 {
+		return false
+	}
+	return true
+}
+
+func gpsRationalToFloat(r exifCommon.Rational) (float64, error) {
+	if r.Denominator == 0 {
+		return 0, fmt.Errorf("gps rational has zero denominator")
+	}
+	return float64(r.Numerator) / float64(r.Denominator), nil
+}
+
+// formatDecimalCoordinate renders a signed decimal-degree value as a display string
+// with its hemisphere letter, for parsers that only hand back decimal degrees (e.g.
+// the exiftool backend) rather than the raw degrees/minutes/seconds rationals.
+func formatDecimalCoordinate(decimal float64, positiveSuffix, negativeSuffix string) string {
+	suffix := positiveSuffix
+	if decimal < 0 {
+		decimal = -decimal
+		suffix = negativeSuffix
+	}
+	return fmt.Sprintf("%.6f°%s", decimal, suffix)
+}
@@ -1,18 +1,13 @@
 package main
 
 import (
-	"encoding/csv"
 	"flag"
 	"fmt"
 	"github.com/dsoprea/go-exif/v3"
 	exifCommon "github.com/dsoprea/go-exif/v3/common"
-	"html/template"
-	"io/fs"
 	"io/ioutil"
 	"log"
-	"os"
-	"path/filepath"
-	"strconv"
+	"runtime"
 	"strings"
 )
 
@@ -21,39 +16,23 @@ const (
 	GPSLatitudeRef  = "GPSLatitudeRef"
 	GPSLongitude    = "GPSLongitude"
 	GPSLatitude     = "GPSLatitude"
-	tplStr          = `
-<!doctype html>
-<html>
-	<head>
-		<meta http-equiv="Content-Type" content="text/html; charset=utf-8">
-	</head>
-	<style>th,td { padding: 10px; font-size:25px; font-face:"Courier New"}</style>
-	<table border='1' style='border-collapse:collapse'>
-		<thead>
-			<tr>
-				<th>File Path</th>
-				<th>Latitude</th>
-				<th>Longitude</th>
-			</tr>
-		</thead>
-		<tbody>
-			{{range $data, $rows := . }}
-				<tr style='padding:5px'>
-					<td>{{ $rows.FilePath }}</td>
-					<td>{{ $rows.Latitude }}</td>
-					<td>{{ $rows.Longitude }}</td>
-				</tr>
-			{{ end }}
-		</tbody>
-	</table>
-</html>
-`
 )
 
-var allowedExtensions = []string{".jpeg", ".jpg", ".png", ".gif"}
+var allowedExtensions = []string{
+	".jpeg", ".jpg", ".png", ".gif",
+	".heic", ".heif", ".cr2", ".nef", ".arw", ".mp4", ".mov",
+}
 
 type exifData struct {
 	FilePath, Latitude, Longitude string
+	Timestamp                     string
+	Camera                        string
+	Orientation                   string
+	Altitude                      string
+	Warning                       string
+	LatitudeDecimal               float64
+	LongitudeDecimal              float64
+	HasDecimalCoordinates         bool
 }
 
 func main() {
@@ -61,6 +40,9 @@ func main() {
 	htmlFlag := flag.Bool("html", false, "denotes whether to generate html file")
 	csvFlag := flag.Bool("csv", false, "denotes whether to generate csv file")
 	rootPath := flag.String("path", "", "root directory path for images")
+	formatFlag := flag.String("format", "", "additional output format to generate: geojson, kml or gpx")
+	workersFlag := flag.Int("workers", runtime.NumCPU(), "number of goroutines used to extract EXIF data concurrently")
+	parserFlag := flag.String("parser", "go-exif", "EXIF backend to use: go-exif or exiftool (needed for HEIC/RAW/video)")
 	flag.Parse()
 
 	var directoryPath = "images" // Default image root directory
@@ -68,73 +50,48 @@ func main() {
 		directoryPath = *rootPath // overwrite if path passed as command line arg
 	}
 
-	exifDataArr := make([]*exifData, 0) // To store exif data of images - filePath, lat, lng
+	parser, closeParser, err := newParser(*parserFlag)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer closeParser()
 
-	// Look for all files in a directory and its sub-directories
-	if err := filepath.WalkDir(directoryPath, func(path string, fileInfo fs.DirEntry, err error) error {
-		if err != nil {
-			log.Println(fmt.Errorf("error while walking directory: %s, with error: %s", path, err))
-			return err
-		}
+	// Walk the directory tree and extract EXIF data from matching files using a
+	// pool of *workersFlag goroutines; results come back sorted by file path.
+	exifDataArr := scanDirectory(directoryPath, *workersFlag, parser)
 
-		if hasValidExtension(fileInfo.Name()) {
-			exifData, extractErr := extractExifDataFromImage(path)
-			if extractErr != nil {
-				log.Println(extractErr)
-			} else {
-				exifDataArr = append(exifDataArr, exifData) // add exif data of images
-			}
-		}
-		return err
-	}); err != nil {
-		log.Println(err)
+	switch *formatFlag {
+	case "geojson":
+		writeToGeoJSON(exifDataArr)
+	case "kml":
+		writeToKML(exifDataArr)
+	case "gpx":
+		writeToGPX(exifDataArr)
+	case "":
+		// no mapping format requested
+	default:
+		log.Println(fmt.Sprintf("unsupported -format value: %s (expected geojson, kml or gpx)", *formatFlag))
 		return
 	}
 
-	if *htmlFlag && !*csvFlag {
+	// -csv/-html are additive with -format, not exclusive: "-csv -format geojson"
+	// writes both the CSV and the GeoJSON. With none of -csv/-html/-format passed,
+	// keep the long-standing default of writing both CSV and HTML.
+	switch {
+	case *htmlFlag && *csvFlag:
+		writeToCSV(exifDataArr)
 		writeToHTML(exifDataArr)
-	} else if !*htmlFlag && *csvFlag {
+	case *htmlFlag:
+		writeToHTML(exifDataArr)
+	case *csvFlag:
 		writeToCSV(exifDataArr)
-	} else {
+	case *formatFlag == "":
 		writeToCSV(exifDataArr)
 		writeToHTML(exifDataArr)
 	}
 }
 
-func writeToCSV(csvDataArr []*exifData) {
-	csvFile, err := createFile("exif-data.csv")
-	if err != nil {
-		log.Println(fmt.Sprintf("Error creating CSV file. Error: %s", err))
-		return
-	}
-	defer closeFile(csvFile)
-	csvWriter := csv.NewWriter(csvFile)
-	_ = csvWriter.Write([]string{"File Path", "Latitude", "Longitude"}) // Columns to be added to CSV
-	for _, csvData := range csvDataArr {
-		_ = csvWriter.Write([]string{csvData.FilePath, csvData.Latitude, csvData.Longitude})
-	}
-	csvWriter.Flush()
-}
-
-func writeToHTML(data []*exifData) {
-	htmlFile, err := createFile("exif-data.html")
-	if err != nil {
-		log.Println(fmt.Sprintf("Error creating HTML file. Error: %s", err))
-		return
-	}
-	defer closeFile(htmlFile)
-
-	tpl, err := template.New("table").Parse(tplStr)
-	if err != nil {
-		panic(err)
-	}
-
-	err = tpl.Execute(htmlFile, data)
-	if err != nil {
-		panic(err)
-	}
-}
-
 func extractExifDataFromImage(imageFilePath string) (*exifData, error) {
 	var (
 		latitudeDirection  string
@@ -143,8 +100,21 @@ func extractExifDataFromImage(imageFilePath string) (*exifData, error) {
 		longitudeValue     string
 		finalLat           string
 		finalLong          string
+		latitudeRational   []exifCommon.Rational
+		longitudeRational  []exifCommon.Rational
+
+		dateTimeOriginal string
+		cameraMake       string
+		model            string
+		orientationValue uint16
+		hasOrientation   bool
+		altitudeValue    float64
+		hasAltitude      bool
+		altitudeRef      uint8
 	)
 
+	var warning string
+
 	data, err := ioutil.ReadFile(imageFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("error reading from file: %s, with error: %s", imageFilePath, err)
@@ -153,14 +123,32 @@ func extractExifDataFromImage(imageFilePath string) (*exifData, error) {
 	exifInfo, err := exif.SearchAndExtractExif(data)
 	if err != nil {
 		if err == exif.ErrNoExif {
-			return nil, fmt.Errorf("no EXIF data found in the file: %s, with error: %s", imageFilePath, err)
+			// No EXIF data is a normal outcome, not a failure: emit a row for this
+			// file with every field set to "Not available" instead of dropping it.
+			return &exifData{
+				FilePath:    imageFilePath,
+				Latitude:    "Not available",
+				Longitude:   "Not available",
+				Camera:      "Not available",
+				Orientation: "Not available",
+				Altitude:    "Not available",
+			}, nil
 		}
-		return nil, fmt.Errorf("error reading exif data from file: %s, with error: %s", imageFilePath, err)
+		return nil, &extractionError{Stage: "search", Path: imageFilePath, Err: err}
 	}
 
 	exifTags, _, err := exif.GetFlatExifDataUniversalSearch(exifInfo, nil, true)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching flat exif data from rawData: %s, with error: %s", imageFilePath, err)
+		flattenErr := &extractionError{Stage: "flatten", Path: imageFilePath, Err: err}
+		if IsCriticalError(err) {
+			return nil, flattenErr
+		}
+		// Non-fatal: a corrupt GPS IFD or a short-read tag value doesn't invalidate
+		// the tags that were already read, so keep going with whatever exifTags
+		// were collected and surface the problem as a warning instead of dropping
+		// the file.
+		warning = flattenErr.Error()
+		log.Println(flattenErr)
 	}
 
 	for _, exifTag := range exifTags {
@@ -172,6 +160,7 @@ func extractExifDataFromImage(imageFilePath string) (*exifData, error) {
 		case GPSLatitude:
 			if gpsPosArr, ok := exifTag.Value.([]exifCommon.Rational); ok {
 				latitudeValue = parseGPSPosition(gpsPosArr)
+				latitudeRational = gpsPosArr
 			}
 		case GPSLongitudeRef:
 			if direction, ok := exifTag.Value.(string); ok {
@@ -180,6 +169,35 @@ func extractExifDataFromImage(imageFilePath string) (*exifData, error) {
 		case GPSLongitude:
 			if gpsPosArr, ok := exifTag.Value.([]exifCommon.Rational); ok {
 				longitudeValue = parseGPSPosition(gpsPosArr)
+				longitudeRational = gpsPosArr
+			}
+		case DateTimeOriginal:
+			if value, ok := exifTag.Value.(string); ok {
+				dateTimeOriginal = value
+			}
+		case Make:
+			if value, ok := exifTag.Value.(string); ok {
+				cameraMake = value
+			}
+		case Model:
+			if value, ok := exifTag.Value.(string); ok {
+				model = value
+			}
+		case Orientation:
+			if shorts, ok := exifTag.Value.([]uint16); ok && len(shorts) > 0 {
+				orientationValue = shorts[0]
+				hasOrientation = true
+			}
+		case GPSAltitude:
+			if rationals, ok := exifTag.Value.([]exifCommon.Rational); ok && len(rationals) > 0 {
+				if value, convErr := gpsRationalToFloat(rationals[0]); convErr == nil {
+					altitudeValue = value
+					hasAltitude = true
+				}
+			}
+		case GPSAltitudeRef:
+			if bytes, ok := exifTag.Value.([]byte); ok && len(bytes) > 0 {
+				altitudeRef = bytes[0]
 			}
 		}
 	}
@@ -196,49 +214,43 @@ func extractExifDataFromImage(imageFilePath string) (*exifData, error) {
 	}
 
 	csvData := &exifData{
-		FilePath:  imageFilePath,
-		Latitude:  finalLat,
-		Longitude: finalLong,
+		FilePath:    imageFilePath,
+		Latitude:    finalLat,
+		Longitude:   finalLong,
+		Timestamp:   dateTimeOriginal,
+		Camera:      formatCamera(cameraMake, model),
+		Orientation: formatOrientation(orientationValue, hasOrientation),
+		Altitude:    formatAltitude(altitudeValue, altitudeRef, hasAltitude),
+		Warning:     warning,
 	}
-	return csvData, nil
-}
 
-func parseGPSPosition(gpsPosArr []exifCommon.Rational) (position string) {
-	if gpsPosArr[0].Denominator != 0 {
-		position = strconv.Itoa(int(gpsPosArr[0].Numerator / gpsPosArr[0].Denominator))
-	} else {
-		position = "0"
-	}
-	position += "Â°"
-	if gpsPosArr[1].Denominator != 0 {
-		position += strconv.Itoa(int(gpsPosArr[1].Numerator / gpsPosArr[1].Denominator))
-	} else {
-		position += "0"
-	}
-	position += "'"
-	if gpsPosArr[2].Denominator != 0 {
-		position += fmt.Sprintf("%.2f''", float32(gpsPosArr[2].Numerator)/float32(gpsPosArr[2].Denominator))
-	} else {
-		position += "0''"
+	if latitudeRational != nil && longitudeRational != nil {
+		lat, latErr := parseGPSDecimal(latitudeRational, latitudeDirection)
+		long, longErr := parseGPSDecimal(longitudeRational, longitudeDirection)
+		if latErr != nil || longErr != nil {
+			log.Printf("discarding unparseable GPS fix for file: %s, lat error: %v, long error: %v", imageFilePath, latErr, longErr)
+			csvData.Latitude = "Not available"
+			csvData.Longitude = "Not available"
+		} else if isValidGPSCoordinate(lat, long) {
+			csvData.LatitudeDecimal = lat
+			csvData.LongitudeDecimal = long
+			csvData.HasDecimalCoordinates = true
+		} else {
+			log.Printf("discarding implausible GPS fix (%f, %f) for file: %s", lat, long, imageFilePath)
+			csvData.Latitude = "Not available"
+			csvData.Longitude = "Not available"
+		}
 	}
-	return
-}
 
-func createFile(fileName string) (*os.File, error) {
-	csvFile, err := os.Create(fileName)
-	if err != nil {
-		log.Fatalf("failed to create file: %s", err)
+	if dateTimeOriginal != "" && csvData.HasDecimalCoordinates {
+		if zonedTimestamp, tzErr := correctTimestampZone(dateTimeOriginal, csvData.LatitudeDecimal, csvData.LongitudeDecimal); tzErr == nil {
+			csvData.Timestamp = zonedTimestamp
+		} else {
+			log.Printf("keeping local timestamp for file: %s, timezone lookup failed: %s", imageFilePath, tzErr)
+		}
 	}
-	return csvFile, err
-}
 
-func closeFile(csvFile *os.File) {
-	func(csvFile *os.File) {
-		err := csvFile.Close()
-		if err != nil {
-			log.Println("Error closing csv file")
-		}
-	}(csvFile)
+	return csvData, nil
 }
 
 func hasValidExtension(name string) bool {
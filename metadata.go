@@ -0,0 +1,62 @@
+package main
+
+import "fmt"
+
+const (
+	DateTimeOriginal = "DateTimeOriginal"
+	Make             = "Make"
+	Model            = "Model"
+	Orientation      = "Orientation"
+	GPSAltitude      = "GPSAltitude"
+	GPSAltitudeRef   = "GPSAltitudeRef"
+
+	// exifTimeLayout is the "YYYY:MM:DD HH:MM:SS" layout EXIF stores DateTimeOriginal in.
+	exifTimeLayout = "2006:01:02 15:04:05"
+)
+
+// formatCamera joins Make and Model into a single human-readable camera name,
+// e.g. "Canon Canon EOS 80D" -> "Canon EOS 80D" is avoided by just concatenating
+// the two fields the way they're written in EXIF (most cameras don't repeat the
+// make in the model).
+func formatCamera(make, model string) string {
+	switch {
+	case make == "" && model == "":
+		return "Not available"
+	case make == "":
+		return model
+	case model == "":
+		return make
+	default:
+		return fmt.Sprintf("%s %s", make, model)
+	}
+}
+
+// formatOrientation renders the numeric EXIF Orientation tag (values 1-8) as
+// returned by the orientation SHORT, falling back to "Not available" when absent.
+func formatOrientation(orientation uint16, present bool) string {
+	if !present {
+		return "Not available"
+	}
+	return fmt.Sprintf("%d", orientation)
+}
+
+// formatAltitude renders GPSAltitude/GPSAltitudeRef as signed meters above (ref 0)
+// or below (ref 1) sea level.
+func formatAltitude(altitude float64, ref uint8, present bool) string {
+	if !present {
+		return "Not available"
+	}
+	if ref == 1 {
+		altitude = -altitude
+	}
+	return formatSignedAltitude(altitude, true)
+}
+
+// formatSignedAltitude renders an already-signed altitude (meters, negative below
+// sea level) - used by parsers such as exiftool that hand back a signed value directly.
+func formatSignedAltitude(altitude float64, present bool) string {
+	if !present {
+		return "Not available"
+	}
+	return fmt.Sprintf("%.2fm", altitude)
+}
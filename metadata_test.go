@@ -0,0 +1,64 @@
+package main
+
+import "testing"
+
+func TestFormatCamera(t *testing.T) {
+	tests := []struct {
+		name, make, model, want string
+	}{
+		{name: "both present", make: "Canon", model: "Canon EOS 80D", want: "Canon Canon EOS 80D"},
+		{name: "make only", make: "Canon", model: "", want: "Canon"},
+		{name: "model only", make: "", model: "Canon EOS 80D", want: "Canon EOS 80D"},
+		{name: "neither", make: "", model: "", want: "Not available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCamera(tt.make, tt.model); got != tt.want {
+				t.Errorf("formatCamera(%q, %q) = %q, want %q", tt.make, tt.model, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatOrientation(t *testing.T) {
+	tests := []struct {
+		name        string
+		orientation uint16
+		present     bool
+		want        string
+	}{
+		{name: "present", orientation: 6, present: true, want: "6"},
+		{name: "absent", orientation: 0, present: false, want: "Not available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatOrientation(tt.orientation, tt.present); got != tt.want {
+				t.Errorf("formatOrientation(%d, %v) = %q, want %q", tt.orientation, tt.present, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAltitude(t *testing.T) {
+	tests := []struct {
+		name     string
+		altitude float64
+		ref      uint8
+		present  bool
+		want     string
+	}{
+		{name: "above sea level", altitude: 123.4, ref: 0, present: true, want: "123.40m"},
+		{name: "below sea level is negated", altitude: 12.5, ref: 1, present: true, want: "-12.50m"},
+		{name: "absent", altitude: 0, ref: 0, present: false, want: "Not available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatAltitude(tt.altitude, tt.ref, tt.present); got != tt.want {
+				t.Errorf("formatAltitude(%f, %d, %v) = %q, want %q", tt.altitude, tt.ref, tt.present, got, tt.want)
+			}
+		})
+	}
+}
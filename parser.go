@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"log"
+)
+
+// ExifParser extracts the metadata this tool catalogs from a single image (or,
+// for the exiftool backend, video/RAW) file. Swapping the implementation lets the
+// same CLI cover formats the active backend can't decode on its own.
+type ExifParser interface {
+	Parse(path string) (*exifData, error)
+}
+
+// goExifParser is the default ExifParser. It's backed by the pure-Go
+// dsoprea/go-exif library already used throughout this package.
+type goExifParser struct{}
+
+func (goExifParser) Parse(path string) (*exifData, error) {
+	return extractExifDataFromImage(path)
+}
+
+// newParser builds the ExifParser selected by the -parser flag, along with a close
+// function the caller must defer to release any backing resources (the exiftool
+// parser needs to shut down its long-lived subprocess).
+func newParser(name string) (parser ExifParser, closeFn func(), err error) {
+	switch name {
+	case "", "go-exif":
+		return goExifParser{}, func() {}, nil
+	case "exiftool":
+		etParser, err := newExiftoolParser()
+		if err != nil {
+			return nil, func() {}, err
+		}
+		return etParser, func() {
+			if closeErr := etParser.Close(); closeErr != nil {
+				log.Println("error closing exiftool:", closeErr)
+			}
+		}, nil
+	default:
+		return nil, func() {}, fmt.Errorf("unsupported -parser value: %s (expected go-exif or exiftool)", name)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+
+	exifCommon "github.com/dsoprea/go-exif/v3/common"
+)
+
+func TestParseGPSDecimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		dms     []exifCommon.Rational
+		ref     string
+		want    float64
+		wantErr bool
+	}{
+		{
+			name: "north",
+			dms: []exifCommon.Rational{
+				{Numerator: 51, Denominator: 1},
+				{Numerator: 30, Denominator: 1},
+				{Numerator: 0, Denominator: 1},
+			},
+			ref:  "N",
+			want: 51.5,
+		},
+		{
+			name: "south is negated",
+			dms: []exifCommon.Rational{
+				{Numerator: 51, Denominator: 1},
+				{Numerator: 30, Denominator: 1},
+				{Numerator: 0, Denominator: 1},
+			},
+			ref:  "S",
+			want: -51.5,
+		},
+		{
+			name: "zero denominator is an error",
+			dms: []exifCommon.Rational{
+				{Numerator: 51, Denominator: 0},
+				{Numerator: 30, Denominator: 1},
+				{Numerator: 0, Denominator: 1},
+			},
+			ref:     "N",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGPSDecimal(tt.dms, tt.ref)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseGPSDecimal() expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseGPSDecimal() unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseGPSDecimal() = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidGPSCoordinate(t *testing.T) {
+	tests := []struct {
+		name      string
+		lat, long float64
+		want      bool
+	}{
+		{name: "plausible fix", lat: 51.5, long: -0.12, want: true},
+		{name: "zero,zero no-fix sentinel is rejected", lat: 0, long: 0, want: false},
+		{name: "latitude out of range", lat: 91, long: 0, want: false},
+		{name: "longitude out of range", lat: 0, long: -181, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isValidGPSCoordinate(tt.lat, tt.long); got != tt.want {
+				t.Errorf("isValidGPSCoordinate(%f, %f) = %v, want %v", tt.lat, tt.long, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// scanDirectory walks directoryPath for files with a supported extension and extracts
+// EXIF data from each one using a pool of workerCount goroutines. Paths are discovered
+// by filepath.WalkDir and fed into a buffered channel (source); each worker consumes
+// paths, calls parser.Parse (parse) and sends the result on an output channel (sink)
+// that is collected here. A progress counter is logged as files complete, and the
+// collected results are sorted by file path before being returned so CSV/HTML output
+// stays deterministic regardless of goroutine scheduling.
+func scanDirectory(directoryPath string, workerCount int, parser ExifParser) []*exifData {
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	paths := make(chan string, workerCount)
+	results := make(chan *exifData)
+
+	var walkErr error
+	go func() {
+		defer close(paths)
+		walkErr = filepath.WalkDir(directoryPath, func(path string, fileInfo fs.DirEntry, err error) error {
+			if err != nil {
+				log.Println(fmt.Errorf("error while walking directory: %s, with error: %s", path, err))
+				return err
+			}
+			if hasValidExtension(fileInfo.Name()) {
+				paths <- path
+			}
+			return nil
+		})
+	}()
+
+	var workers sync.WaitGroup
+	workers.Add(workerCount)
+	for i := 0; i < workerCount; i++ {
+		go func() {
+			defer workers.Done()
+			for path := range paths {
+				data, extractErr := parser.Parse(path)
+				if extractErr != nil {
+					log.Println(extractErr)
+					continue
+				}
+				results <- data
+			}
+		}()
+	}
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	exifDataArr := make([]*exifData, 0)
+	processed := 0
+	for data := range results {
+		exifDataArr = append(exifDataArr, data)
+		processed++
+		log.Printf("processed %d file(s)", processed)
+	}
+
+	if walkErr != nil {
+		log.Println(walkErr)
+	}
+
+	sort.Slice(exifDataArr, func(i, j int) bool {
+		return exifDataArr[i].FilePath < exifDataArr[j].FilePath
+	})
+
+	return exifDataArr
+}
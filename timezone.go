@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tz "github.com/ugjka/go-tz/v2"
+)
+
+// correctTimestampZone takes a DateTimeOriginal value as EXIF stores it (no timezone
+// information) and, given a valid GPS fix, resolves the IANA zone the photo was taken
+// in and re-renders the timestamp with that zone's offset. If the zone cannot be
+// resolved or loaded, the original local timestamp is returned unchanged and the
+// caller is expected to log the fallback.
+func correctTimestampZone(rawTimestamp string, latitude, longitude float64) (string, error) {
+	localTime, err := time.Parse(exifTimeLayout, rawTimestamp)
+	if err != nil {
+		return rawTimestamp, fmt.Errorf("error parsing timestamp: %s, with error: %s", rawTimestamp, err)
+	}
+
+	zones, err := tz.GetZone(tz.Point{Lat: latitude, Lon: longitude})
+	if err != nil || len(zones) == 0 {
+		return rawTimestamp, fmt.Errorf("error resolving timezone for (%f, %f): %s", latitude, longitude, err)
+	}
+
+	location, err := time.LoadLocation(zones[0])
+	if err != nil {
+		return rawTimestamp, fmt.Errorf("error loading timezone %s: %s", zones[0], err)
+	}
+
+	zonedTime := time.Date(
+		localTime.Year(), localTime.Month(), localTime.Day(),
+		localTime.Hour(), localTime.Minute(), localTime.Second(), 0,
+		location,
+	)
+	return zonedTime.Format(time.RFC3339), nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestCorrectTimestampZone(t *testing.T) {
+	tests := []struct {
+		name         string
+		rawTimestamp string
+		latitude     float64
+		longitude    float64
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "DST offset applied in summer",
+			rawTimestamp: "2023:06:15 12:00:00",
+			latitude:     51.5074, longitude: -0.1278, // London
+			want: "2023-06-15T12:00:00+01:00",
+		},
+		{
+			name:         "no DST offset in winter",
+			rawTimestamp: "2023:01:15 12:00:00",
+			latitude:     51.5074, longitude: -0.1278, // London
+			want: "2023-01-15T12:00:00Z",
+		},
+		{
+			name:         "unparseable timestamp falls back unchanged",
+			rawTimestamp: "not-a-timestamp",
+			latitude:     51.5074, longitude: -0.1278,
+			want:    "not-a-timestamp",
+			wantErr: true,
+		},
+		{
+			name:         "out-of-range coordinates fall back unchanged",
+			rawTimestamp: "2023:06:15 12:00:00",
+			latitude:     1000, longitude: 1000,
+			want:    "2023:06:15 12:00:00",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := correctTimestampZone(tt.rawTimestamp, tt.latitude, tt.longitude)
+			if tt.wantErr && err == nil {
+				t.Fatalf("correctTimestampZone() expected an error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("correctTimestampZone() unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("correctTimestampZone() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+)
+
+const tplStr = `
+<!doctype html>
+<html>
+	<head>
+		<meta http-equiv="Content-Type" content="text/html; charset=utf-8">
+	</head>
+	<style>th,td { padding: 10px; font-size:25px; font-face:"Courier New"}</style>
+	<table border='1' style='border-collapse:collapse'>
+		<thead>
+			<tr>
+				<th>File Path</th>
+				<th>Latitude</th>
+				<th>Longitude</th>
+				<th>Timestamp</th>
+				<th>Camera</th>
+				<th>Orientation</th>
+				<th>Altitude</th>
+				<th>Warning</th>
+			</tr>
+		</thead>
+		<tbody>
+			{{range $data, $rows := . }}
+				<tr style='padding:5px'>
+					<td>{{ $rows.FilePath }}</td>
+					<td>{{ $rows.Latitude }}</td>
+					<td>{{ $rows.Longitude }}</td>
+					<td>{{ $rows.Timestamp }}</td>
+					<td>{{ $rows.Camera }}</td>
+					<td>{{ $rows.Orientation }}</td>
+					<td>{{ $rows.Altitude }}</td>
+					<td>{{ $rows.Warning }}</td>
+				</tr>
+			{{ end }}
+		</tbody>
+	</table>
+</html>
+`
+
+func writeToCSV(csvDataArr []*exifData) {
+	csvFile, err := createFile("exif-data.csv")
+	if err != nil {
+		log.Println(fmt.Sprintf("Error creating CSV file. Error: %s", err))
+		return
+	}
+	defer closeFile(csvFile)
+	csvWriter := csv.NewWriter(csvFile)
+	_ = csvWriter.Write([]string{"File Path", "Latitude", "Longitude", "Timestamp", "Camera", "Orientation", "Altitude", "Warning"}) // Columns to be added to CSV
+	for _, csvData := range csvDataArr {
+		_ = csvWriter.Write([]string{
+			csvData.FilePath, csvData.Latitude, csvData.Longitude,
+			csvData.Timestamp, csvData.Camera, csvData.Orientation, csvData.Altitude,
+			csvData.Warning,
+		})
+	}
+	csvWriter.Flush()
+}
+
+func writeToHTML(data []*exifData) {
+	htmlFile, err := createFile("exif-data.html")
+	if err != nil {
+		log.Println(fmt.Sprintf("Error creating HTML file. Error: %s", err))
+		return
+	}
+	defer closeFile(htmlFile)
+
+	tpl, err := template.New("table").Parse(tplStr)
+	if err != nil {
+		panic(err)
+	}
+
+	err = tpl.Execute(htmlFile, data)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// geoJSONFeatureCollection and geoJSONFeature mirror the subset of the GeoJSON
+// spec (RFC 7946) needed to plot points on a map.
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONPoint           `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONPoint struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+func writeToGeoJSON(data []*exifData) {
+	geoJSONFile, err := createFile("exif-data.geojson")
+	if err != nil {
+		log.Println(fmt.Sprintf("Error creating GeoJSON file. Error: %s", err))
+		return
+	}
+	defer closeFile(geoJSONFile)
+
+	collection := geoJSONFeatureCollection{Type: "FeatureCollection"}
+	for _, d := range data {
+		if !d.HasDecimalCoordinates {
+			continue
+		}
+		properties := map[string]interface{}{"filePath": d.FilePath}
+		if d.Timestamp != "" {
+			properties["timestamp"] = d.Timestamp
+		}
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type: "Feature",
+			Geometry: geoJSONPoint{
+				Type:        "Point",
+				Coordinates: []float64{d.LongitudeDecimal, d.LatitudeDecimal},
+			},
+			Properties: properties,
+		})
+	}
+
+	encoder := json.NewEncoder(geoJSONFile)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(collection); err != nil {
+		log.Println(fmt.Sprintf("Error encoding GeoJSON. Error: %s", err))
+	}
+}
+
+// kmlDocument, kmlPlacemark and kmlPoint model the minimal subset of KML
+// (Keyhole Markup Language) needed to render placemarks in tools like Google Earth.
+type kmlDocument struct {
+	XMLName xml.Name `xml:"kml"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Doc     kmlDoc   `xml:"Document"`
+}
+
+type kmlDoc struct {
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string   `xml:"name"`
+	Description string   `xml:"description"`
+	Point       kmlPoint `xml:"Point"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+func writeToKML(data []*exifData) {
+	kmlFile, err := createFile("exif-data.kml")
+	if err != nil {
+		log.Println(fmt.Sprintf("Error creating KML file. Error: %s", err))
+		return
+	}
+	defer closeFile(kmlFile)
+
+	doc := kmlDocument{Xmlns: "http://www.opengis.net/kml/2.2"}
+	for _, d := range data {
+		if !d.HasDecimalCoordinates {
+			continue
+		}
+		description := d.FilePath
+		if d.Timestamp != "" {
+			description = fmt.Sprintf("%s (%s)", d.FilePath, d.Timestamp)
+		}
+		doc.Doc.Placemarks = append(doc.Doc.Placemarks, kmlPlacemark{
+			Name:        d.FilePath,
+			Description: description,
+			Point:       kmlPoint{Coordinates: fmt.Sprintf("%f,%f", d.LongitudeDecimal, d.LatitudeDecimal)},
+		})
+	}
+
+	kmlFile.WriteString(xml.Header)
+	encoder := xml.NewEncoder(kmlFile)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		log.Println(fmt.Sprintf("Error encoding KML. Error: %s", err))
+	}
+}
+
+// gpxDocument and gpxWaypoint model the minimal subset of GPX (GPS Exchange Format)
+// needed to represent a set of waypoints.
+type gpxDocument struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+}
+
+type gpxWaypoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Name string  `xml:"name"`
+	Time string  `xml:"time,omitempty"`
+}
+
+func writeToGPX(data []*exifData) {
+	gpxFile, err := createFile("exif-data.gpx")
+	if err != nil {
+		log.Println(fmt.Sprintf("Error creating GPX file. Error: %s", err))
+		return
+	}
+	defer closeFile(gpxFile)
+
+	doc := gpxDocument{Version: "1.1", Creator: "go-exif-gps-extract"}
+	for _, d := range data {
+		if !d.HasDecimalCoordinates {
+			continue
+		}
+		doc.Waypoints = append(doc.Waypoints, gpxWaypoint{
+			Lat:  d.LatitudeDecimal,
+			Lon:  d.LongitudeDecimal,
+			Name: d.FilePath,
+			Time: d.Timestamp,
+		})
+	}
+
+	gpxFile.WriteString(xml.Header)
+	encoder := xml.NewEncoder(gpxFile)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		log.Println(fmt.Sprintf("Error encoding GPX. Error: %s", err))
+	}
+}
+
+func createFile(fileName string) (*os.File, error) {
+	csvFile, err := os.Create(fileName)
+	if err != nil {
+		log.Fatalf("failed to create file: %s", err)
+	}
+	return csvFile, err
+}
+
+func closeFile(csvFile *os.File) {
+	func(csvFile *os.File) {
+		err := csvFile.Close()
+		if err != nil {
+			log.Println("Error closing csv file")
+		}
+	}(csvFile)
+}